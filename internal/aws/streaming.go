@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/service/transcribestreaming"
+	tstypes "github.com/aws/aws-sdk-go-v2/service/transcribestreaming/types"
+
+	"github.com/embano1/transcribe-meetings/internal/media"
+	appTypes "github.com/embano1/transcribe-meetings/internal/types"
+)
+
+// audioChunkSize is the size of each AudioEvent frame sent over the HTTP/2 event
+// stream, mirroring the chunking used by the GStreamer AWS transcriber plugin.
+const audioChunkSize = 8 * 1024
+
+// TranscriptEvent is a partial or finalized transcription result delivered while
+// streaming.
+type TranscriptEvent struct {
+	Text string
+	// Speaker is the label of the speaker attributed to this result (e.g.
+	// "spk_0"), populated only when ShowSpeakerLabel was requested and Transcribe
+	// identified a speaker for it.
+	Speaker   string
+	IsPartial bool
+}
+
+// StreamingTranscribeService transcribes audio in near-real-time using Amazon
+// Transcribe Streaming, as an alternative to the batch upload-to-S3-then-poll flow.
+type StreamingTranscribeService struct {
+	client *transcribestreaming.Client
+}
+
+// NewStreamingTranscribeService creates a new streaming Transcribe service.
+func NewStreamingTranscribeService(client *transcribestreaming.Client) *StreamingTranscribeService {
+	return &StreamingTranscribeService{client: client}
+}
+
+// Transcribe transcodes audio read from r to raw PCM, streams it to Amazon
+// Transcribe, and returns a channel of TranscriptEvent values as partial and
+// final results arrive. The channel is closed once r is exhausted and the
+// stream is drained, or ctx is cancelled.
+func (t *StreamingTranscribeService) Transcribe(ctx context.Context, r io.Reader, cfg *appTypes.AppConfig) (<-chan TranscriptEvent, error) {
+	sampleRate := int32(cfg.SampleRate)
+
+	pcm, err := media.TranscodePCM(ctx, r, cfg.SampleRate)
+	if err != nil {
+		return nil, fmt.Errorf("transcode audio to PCM for streaming: %w", err)
+	}
+
+	resp, err := t.client.StartStreamTranscription(ctx, &transcribestreaming.StartStreamTranscriptionInput{
+		LanguageCode:         tstypes.LanguageCode(cfg.LanguageCode),
+		MediaEncoding:        tstypes.MediaEncodingPcm,
+		MediaSampleRateHertz: &sampleRate,
+		ShowSpeakerLabel:     cfg.SpeakerDiarization,
+	})
+	if err != nil {
+		pcm.Close()
+		return nil, fmt.Errorf("start stream transcription: %w", err)
+	}
+
+	stream := resp.GetStream()
+	events := make(chan TranscriptEvent)
+
+	go func() {
+		sendAudioEvents(ctx, stream, pcm)
+		pcm.Close()
+	}()
+	go receiveTranscriptEvents(ctx, stream, events)
+
+	return events, nil
+}
+
+// sendAudioEvents reads r in audioChunkSize frames and forwards each as an
+// AudioEvent until r is exhausted, ctx is cancelled, or a send fails.
+func sendAudioEvents(ctx context.Context, stream *transcribestreaming.StartStreamTranscriptionEventStream, r io.Reader) {
+	defer stream.Writer.Close()
+
+	buf := make([]byte, audioChunkSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		n, err := r.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			event := &tstypes.AudioStreamMemberAudioEvent{Value: tstypes.AudioEvent{AudioChunk: chunk}}
+			if sendErr := stream.Writer.Send(ctx, event); sendErr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// receiveTranscriptEvents drains partial and final TranscriptEvents from stream and
+// forwards them on events until the stream closes or ctx is cancelled.
+func receiveTranscriptEvents(ctx context.Context, stream *transcribestreaming.StartStreamTranscriptionEventStream, events chan<- TranscriptEvent) {
+	defer close(events)
+	defer stream.Reader.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-stream.Reader.Events():
+			if !ok {
+				return
+			}
+			transcriptEvent, ok := e.(*tstypes.TranscriptResultStreamMemberTranscriptEvent)
+			if !ok || transcriptEvent.Value.Transcript == nil {
+				continue
+			}
+			for _, result := range transcriptEvent.Value.Transcript.Results {
+				if len(result.Alternatives) == 0 || result.Alternatives[0].Transcript == nil {
+					continue
+				}
+				select {
+				case events <- TranscriptEvent{
+					Text:      *result.Alternatives[0].Transcript,
+					Speaker:   resultSpeaker(result.Alternatives[0].Items),
+					IsPartial: result.IsPartial,
+				}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+// resultSpeaker returns the label of the first item carrying a speaker
+// attribution, or "" if none of items has one (e.g. ShowSpeakerLabel was not
+// requested).
+func resultSpeaker(items []tstypes.Item) string {
+	for _, item := range items {
+		if item.Speaker != nil {
+			return *item.Speaker
+		}
+	}
+	return ""
+}
@@ -82,25 +82,47 @@ func (t *TranscribeService) getTranscriptionJobStatus(ctx context.Context, jobNa
 
 // startTranscriptionJob starts a transcription job using the provided S3 file.
 func (t *TranscribeService) startTranscriptionJob(ctx context.Context, jobName, bucket, mediaKey string, cfg *appTypes.AppConfig) error {
+	mediaFormat := cfg.MediaFormat
+	if mediaFormat == "" {
+		mediaFormat = "m4a"
+	}
+
 	mediaURI := fmt.Sprintf("s3://%s/%s", bucket, mediaKey)
 	input := &transcribe.StartTranscriptionJobInput{
 		TranscriptionJobName: &jobName,
 		LanguageCode:         types.LanguageCode(cfg.LanguageCode),
-		MediaFormat:          "m4a",
+		MediaFormat:          types.MediaFormat(mediaFormat),
 		Media: &types.Media{
 			MediaFileUri: &mediaURI,
 		},
 		OutputBucketName: &bucket,
 	}
 
+	settings, hasSettings := &types.Settings{}, false
+
 	// Add speaker diarization settings if enabled
 	if cfg.SpeakerDiarization {
 		maxSpeakers := int32(cfg.MaxSpeakers)
-		input.Settings = &types.Settings{
-			ShowSpeakerLabels:  &cfg.SpeakerDiarization,
-			MaxSpeakerLabels:   &maxSpeakers,
-		}
+		settings.ShowSpeakerLabels = &cfg.SpeakerDiarization
+		settings.MaxSpeakerLabels = &maxSpeakers
+		hasSettings = true
+	}
+
+	// Attach the resolved custom vocabulary and vocabulary filter, if any.
+	if cfg.ResolvedVocabularyName != "" {
+		settings.VocabularyName = &cfg.ResolvedVocabularyName
+		hasSettings = true
+	}
+	if cfg.ResolvedVocabularyFilterName != "" {
+		settings.VocabularyFilterName = &cfg.ResolvedVocabularyFilterName
+		settings.VocabularyFilterMethod = types.VocabularyFilterMethod(cfg.VocabFilterMethod)
+		hasSettings = true
 	}
+
+	if hasSettings {
+		input.Settings = settings
+	}
+
 	_, err := t.client.StartTranscriptionJob(ctx, input)
 	return err
 }
\ No newline at end of file
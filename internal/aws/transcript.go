@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/embano1/transcribe-meetings/internal/formatting"
+	"github.com/embano1/transcribe-meetings/internal/types"
+)
+
+// RenderTranscript decodes the Transcribe result JSON read from r and writes the
+// rendered transcript to w in the format requested by cfg.OutputFormat (defaulting
+// to plain text). srt and vtt are streamed: formatting.StreamCues consumes
+// results.items as it decodes them and cues are written out as soon as they
+// close, so memory stays flat regardless of meeting length. The other formats
+// need the whole result in memory anyway (json re-encodes the decoded result,
+// and plain text needs the full transcript string), so those decode it in full.
+// The json output holds only the fields that types.TranscriptionResult models;
+// any other fields of the original document are dropped.
+func RenderTranscript(r io.Reader, cfg *types.AppConfig, w io.Writer) error {
+	switch cfg.OutputFormat {
+	case types.FormatSRT:
+		srt := formatting.NewSRTWriter(w)
+		n, err := formatting.StreamCues(json.NewDecoder(r), cfg, srt.WriteCue)
+		if err != nil {
+			return fmt.Errorf("stream SRT cues: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("no transcript found in result")
+		}
+		return nil
+	case types.FormatWebVTT:
+		vtt, err := formatting.NewWebVTTWriter(w)
+		if err != nil {
+			return err
+		}
+		n, err := formatting.StreamCues(json.NewDecoder(r), cfg, vtt.WriteCue)
+		if err != nil {
+			return fmt.Errorf("stream WebVTT cues: %w", err)
+		}
+		if n == 0 {
+			return fmt.Errorf("no transcript found in result")
+		}
+		return nil
+	}
+
+	var result types.TranscriptionResult
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Results.Transcripts) == 0 {
+		return fmt.Errorf("no transcript found in result")
+	}
+
+	switch cfg.OutputFormat {
+	case types.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(&result)
+	default:
+		// If speaker diarization is enabled and speaker labels are available, format with speakers
+		text := result.Results.Transcripts[0].Transcript
+		if cfg.SpeakerDiarization && result.Results.SpeakerLabels != nil {
+			text = formatting.FormatTranscriptWithSpeakers(&result)
+		}
+		_, err := io.WriteString(w, text)
+		return err
+	}
+}
@@ -0,0 +1,194 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/transcribe"
+	"github.com/aws/aws-sdk-go-v2/service/transcribe/types"
+)
+
+// VocabularyService manages the lifecycle of Transcribe custom vocabularies and
+// vocabulary filters.
+type VocabularyService struct {
+	client *transcribe.Client
+}
+
+// NewVocabularyService creates a new vocabulary service.
+func NewVocabularyService(client *transcribe.Client) *VocabularyService {
+	return &VocabularyService{client: client}
+}
+
+// CreateVocabulary creates a custom vocabulary named name from phrases.
+func (v *VocabularyService) CreateVocabulary(ctx context.Context, name, languageCode string, phrases []string) error {
+	_, err := v.client.CreateVocabulary(ctx, &transcribe.CreateVocabularyInput{
+		VocabularyName: &name,
+		LanguageCode:   types.LanguageCode(languageCode),
+		Phrases:        phrases,
+	})
+	return err
+}
+
+// GetVocabulary returns the named vocabulary and whether it exists.
+func (v *VocabularyService) GetVocabulary(ctx context.Context, name string) (*transcribe.GetVocabularyOutput, bool, error) {
+	out, err := v.client.GetVocabulary(ctx, &transcribe.GetVocabularyInput{VocabularyName: &name})
+	if err != nil {
+		if isVocabularyNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// UpdateVocabulary replaces the phrases of an existing vocabulary, e.g. to retry a
+// vocabulary build that previously failed.
+func (v *VocabularyService) UpdateVocabulary(ctx context.Context, name, languageCode string, phrases []string) error {
+	_, err := v.client.UpdateVocabulary(ctx, &transcribe.UpdateVocabularyInput{
+		VocabularyName: &name,
+		LanguageCode:   types.LanguageCode(languageCode),
+		Phrases:        phrases,
+	})
+	return err
+}
+
+// CreateVocabularyFilter creates a vocabulary filter named name from words.
+func (v *VocabularyService) CreateVocabularyFilter(ctx context.Context, name, languageCode string, words []string) error {
+	_, err := v.client.CreateVocabularyFilter(ctx, &transcribe.CreateVocabularyFilterInput{
+		VocabularyFilterName: &name,
+		LanguageCode:         types.LanguageCode(languageCode),
+		Words:                words,
+	})
+	return err
+}
+
+// DeleteVocabulary deletes the named vocabulary.
+func (v *VocabularyService) DeleteVocabulary(ctx context.Context, name string) error {
+	_, err := v.client.DeleteVocabulary(ctx, &transcribe.DeleteVocabularyInput{VocabularyName: &name})
+	return err
+}
+
+// EnsureVocabulary hashes the contents of vocabularyFile, names the remote
+// vocabulary "<name>-<hash>" so a content change produces a new vocabulary rather
+// than silently reusing stale terms, creates it if missing (retrying via
+// UpdateVocabulary if a prior build failed), and waits for it to reach READY.
+func (v *VocabularyService) EnsureVocabulary(ctx context.Context, name, vocabularyFile, languageCode string) (string, error) {
+	contents, err := os.ReadFile(vocabularyFile)
+	if err != nil {
+		return "", fmt.Errorf("read vocabulary file: %w", err)
+	}
+	phrases := parseWordList(contents)
+	vocabName := hashedName(name, contents)
+
+	out, exists, err := v.GetVocabulary(ctx, vocabName)
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("check vocabulary %q: %w", vocabName, err)
+	case !exists:
+		log.Printf("Creating vocabulary %q...", vocabName)
+		if err := v.CreateVocabulary(ctx, vocabName, languageCode, phrases); err != nil {
+			return "", fmt.Errorf("create vocabulary %q: %w", vocabName, err)
+		}
+	case out.VocabularyState == types.VocabularyStateFailed:
+		log.Printf("Vocabulary %q previously failed to build; retrying.", vocabName)
+		if err := v.UpdateVocabulary(ctx, vocabName, languageCode, phrases); err != nil {
+			return "", fmt.Errorf("update vocabulary %q: %w", vocabName, err)
+		}
+	default:
+		log.Printf("Vocabulary %q already exists; reusing.", vocabName)
+	}
+
+	if err := v.waitForReady(ctx, vocabName); err != nil {
+		return "", fmt.Errorf("wait for vocabulary %q: %w", vocabName, err)
+	}
+	return vocabName, nil
+}
+
+// EnsureVocabularyFilter hashes the contents of filterFile, names the remote filter
+// "<name>-<hash>", and creates it if missing.
+func (v *VocabularyService) EnsureVocabularyFilter(ctx context.Context, name, filterFile, languageCode string) (string, error) {
+	contents, err := os.ReadFile(filterFile)
+	if err != nil {
+		return "", fmt.Errorf("read vocabulary filter file: %w", err)
+	}
+	filterName := hashedName(name, contents)
+
+	_, err = v.client.GetVocabularyFilter(ctx, &transcribe.GetVocabularyFilterInput{VocabularyFilterName: &filterName})
+	switch {
+	case err == nil:
+		log.Printf("Vocabulary filter %q already exists; reusing.", filterName)
+		return filterName, nil
+	case !isVocabularyNotFound(err):
+		return "", fmt.Errorf("check vocabulary filter %q: %w", filterName, err)
+	}
+
+	log.Printf("Creating vocabulary filter %q...", filterName)
+	words := parseWordList(contents)
+	if err := v.CreateVocabularyFilter(ctx, filterName, languageCode, words); err != nil {
+		return "", fmt.Errorf("create vocabulary filter %q: %w", filterName, err)
+	}
+	return filterName, nil
+}
+
+// waitForReady polls the named vocabulary until it reaches READY or FAILED.
+func (v *VocabularyService) waitForReady(ctx context.Context, name string) error {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			out, exists, err := v.GetVocabulary(ctx, name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("vocabulary %q disappeared while waiting", name)
+			}
+			switch out.VocabularyState {
+			case types.VocabularyStateReady:
+				return nil
+			case types.VocabularyStateFailed:
+				reason := ""
+				if out.FailureReason != nil {
+					reason = *out.FailureReason
+				}
+				return fmt.Errorf("vocabulary build failed: %s", reason)
+			}
+			log.Printf("Vocabulary %q state: %s", name, out.VocabularyState)
+		}
+	}
+}
+
+// hashedName derives a content-addressed vocabulary/filter name, following the same
+// "hash content, reuse if present" idiom used for transcription job names.
+func hashedName(name string, contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return fmt.Sprintf("%s-%s", name, hex.EncodeToString(sum[:])[:16])
+}
+
+// parseWordList splits vocabulary/filter file contents into non-empty, trimmed
+// entries, one per line.
+func parseWordList(contents []byte) []string {
+	lines := strings.Split(string(contents), "\n")
+	words := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			words = append(words, line)
+		}
+	}
+	return words
+}
+
+func isVocabularyNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "NotFoundException")
+}
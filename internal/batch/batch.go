@@ -0,0 +1,76 @@
+package batch
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job describes a single file's place in a batch run.
+type Job struct {
+	InputPath  string
+	OutputPath string
+	JobName    string
+	S3Key      string
+}
+
+// Result is the outcome of processing one Job.
+type Result struct {
+	Job
+	Err      error
+	Duration time.Duration
+}
+
+// Process is the per-file pipeline the caller supplies; it is invoked once per Job
+// from a worker goroutine and returns the job with any fields (e.g. JobName, S3Key)
+// it derived along the way.
+type Process func(ctx context.Context, job Job) (Job, error)
+
+// Run executes process for each job using a bounded worker pool of size
+// concurrency, logging a status line as each job starts and finishes.
+func Run(ctx context.Context, jobs []Job, concurrency int, process Process) []Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]Result, len(jobs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, job Job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			log.Printf("[%s] starting", job.InputPath)
+			finalJob, err := process(ctx, job)
+			duration := time.Since(start)
+			if err != nil {
+				log.Printf("[%s] failed after %s: %v", job.InputPath, duration.Round(time.Second), err)
+			} else {
+				log.Printf("[%s] completed in %s", job.InputPath, duration.Round(time.Second))
+			}
+			results[i] = Result{Job: finalJob, Err: err, Duration: duration}
+		}(i, job)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// Summarize logs an aggregate pass/fail count for a completed batch.
+func Summarize(results []Result) {
+	var ok, failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		} else {
+			ok++
+		}
+	}
+	log.Printf("Batch complete: %d succeeded, %d failed, %d total", ok, failed, len(results))
+}
@@ -0,0 +1,59 @@
+// Package batch runs the upload/transcribe/download pipeline concurrently over a
+// directory (or glob) of recordings.
+package batch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// mediaExtensions lists the file extensions considered audio/video recordings when
+// walking a directory.
+var mediaExtensions = map[string]bool{
+	".m4a": true, ".mp3": true, ".wav": true, ".flac": true,
+	".mp4": true, ".webm": true, ".opus": true, ".ogg": true,
+}
+
+// IsBatch reports whether pattern refers to a directory or a glob, as opposed to a
+// single literal file.
+func IsBatch(pattern string) bool {
+	if info, err := os.Stat(pattern); err == nil {
+		return info.IsDir()
+	}
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// Discover resolves pattern to a sorted list of input file paths. pattern may be a
+// directory (whose immediate contents are filtered to recognized media
+// extensions) or a glob.
+func Discover(pattern string) ([]string, error) {
+	info, err := os.Stat(pattern)
+	if err == nil && info.IsDir() {
+		entries, err := os.ReadDir(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("read directory %q: %w", pattern, err)
+		}
+
+		var files []string
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			if mediaExtensions[strings.ToLower(filepath.Ext(e.Name()))] {
+				files = append(files, filepath.Join(pattern, e.Name()))
+			}
+		}
+		sort.Strings(files)
+		return files, nil
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("expand glob %q: %w", pattern, err)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
@@ -0,0 +1,46 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ManifestEntry records one job's identifiers and outcome for downstream tooling.
+type ManifestEntry struct {
+	InputPath      string `json:"input_path"`
+	JobName        string `json:"job_name"`
+	S3Key          string `json:"s3_key"`
+	TranscriptPath string `json:"transcript_path"`
+	Error          string `json:"error,omitempty"`
+}
+
+// NewManifest builds the manifest entries for a completed batch run.
+func NewManifest(results []Result) []ManifestEntry {
+	entries := make([]ManifestEntry, 0, len(results))
+	for _, r := range results {
+		entry := ManifestEntry{
+			InputPath:      r.InputPath,
+			JobName:        r.JobName,
+			S3Key:          r.S3Key,
+			TranscriptPath: r.OutputPath,
+		}
+		if r.Err != nil {
+			entry.Error = r.Err.Error()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteManifest writes entries as indented JSON to path.
+func WriteManifest(path string, entries []ManifestEntry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write manifest %q: %w", path, err)
+	}
+	return nil
+}
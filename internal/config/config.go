@@ -3,10 +3,14 @@ package config
 import (
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/embano1/transcribe-meetings/internal/batch"
+	"github.com/embano1/transcribe-meetings/internal/media"
 	"github.com/embano1/transcribe-meetings/internal/types"
 )
 
@@ -20,13 +24,26 @@ var (
 func New(args []string) (*types.AppConfig, error) {
 	fs := flag.NewFlagSet("", flag.ExitOnError)
 
-	inputFilePath := fs.String("f", "", "Path to input m4a audio file")
-	outputFilePath := fs.String("o", "", "Path to output text file")
+	inputFilePath := fs.String("f", "", "Path to input audio/video file, directory, or glob (format is auto-detected)")
+	outputFilePath := fs.String("o", "", "Path to output text file (single-file mode)")
 	bucketName := fs.String("b", "", "S3 bucket name")
 	region := fs.String("r", "us-east-1", "AWS region")
 	languageCode := fs.String("l", "en-US", "Language code for transcription")
 	speakerDiarization := fs.Bool("d", false, "Enable speaker diarization")
 	maxSpeakers := fs.Int("m", 10, "Maximum number of speakers for diarization")
+	outputFormat := fs.String("format", "txt", "Output format: txt|srt|vtt|json")
+	stream := fs.Bool("stream", false, "Transcribe a local file or stdin (\"-\") in near-real-time using Transcribe Streaming")
+	storageURL := fs.String("storage", "", "Object store URL for the input upload (s3://bucket, file:///path, gs://bucket, http(s)://minio-host/bucket); defaults to s3://<bucket name>")
+	storageCheck := fs.Bool("storage-check", false, "Upload -f to -storage, read it back, then exit without running Transcribe; lets file://, gs://, and S3-compatible endpoints be tested on their own, since Transcribe itself can only read media from Amazon S3")
+	transcode := fs.String("transcode", "auto", "Transcode unsupported input formats before upload: auto|never|always")
+	sampleRate := fs.Int("sample-rate", 16000, "Target audio sample rate (Hz) used when transcoding")
+	vocabularyFile := fs.String("vocabulary-file", "", "Path to a custom vocabulary file (one phrase per line)")
+	vocabularyName := fs.String("vocabulary-name", "", "Base name for the custom vocabulary and vocabulary filter; required with -vocabulary-file or -vocab-filter-file")
+	vocabFilterFile := fs.String("vocab-filter-file", "", "Path to a vocabulary filter file (one word per line)")
+	vocabFilterMethod := fs.String("vocab-filter-method", "mask", "Vocabulary filter method: mask|remove|tag")
+	outputDir := fs.String("output-dir", "", "Directory to write transcripts to in batch mode (required when -f is a directory or glob)")
+	concurrency := fs.Int("concurrency", 4, "Number of recordings to process concurrently in batch mode")
+	manifestPath := fs.String("manifest", "", "Path to write a JSON manifest of batch job names, object keys, and transcript paths")
 	version := fs.Bool("v", false, "Print version and exit")
 
 	if err := fs.Parse(args); err != nil {
@@ -38,22 +55,94 @@ func New(args []string) (*types.AppConfig, error) {
 	}
 
 	// fail fast
-	if *inputFilePath == "" || *outputFilePath == "" || *bucketName == "" {
+	if *inputFilePath == "" {
 		fs.Usage()
 		os.Exit(1)
 	}
 
-	if !strings.HasSuffix(strings.ToLower(*inputFilePath), ".m4a") {
-		return nil, fmt.Errorf("input file must be an m4a file")
+	isBatch := batch.IsBatch(*inputFilePath)
+	if isBatch {
+		if *outputDir == "" {
+			return nil, fmt.Errorf("-output-dir is required when -f is a directory or glob")
+		}
+	} else if *outputFilePath == "" {
+		fs.Usage()
+		os.Exit(1)
 	}
 
-	isValid, err := validateBucketName(*bucketName)
-	if err != nil {
-		return nil, fmt.Errorf("invalid bucket name %q: %w", *bucketName, err)
+	// Streaming mode transcribes directly from the input without the
+	// upload-to-S3-then-poll flow, so it needs no bucket. Neither does
+	// -storage-check, which only exercises the configured storage backend.
+	if !*stream && !*storageCheck {
+		if *bucketName == "" {
+			fs.Usage()
+			os.Exit(1)
+		}
+	}
+
+	if *bucketName != "" {
+		isValid, err := validateBucketName(*bucketName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket name %q: %w", *bucketName, err)
+		}
+
+		if !isValid {
+			return nil, fmt.Errorf("invalid bucket name %q", *bucketName)
+		}
+	}
+
+	format := types.OutputFormat(strings.ToLower(*outputFormat))
+	switch format {
+	case types.FormatText, types.FormatSRT, types.FormatWebVTT, types.FormatJSON:
+	default:
+		return nil, fmt.Errorf("invalid output format %q: must be one of txt|srt|vtt|json", *outputFormat)
+	}
+
+	transcodeMode := media.TranscodeMode(strings.ToLower(*transcode))
+	switch transcodeMode {
+	case media.TranscodeAuto, media.TranscodeNever, media.TranscodeAlways:
+	default:
+		return nil, fmt.Errorf("invalid transcode mode %q: must be one of auto|never|always", *transcode)
+	}
+
+	store := *storageURL
+	if store == "" && *bucketName != "" {
+		store = fmt.Sprintf("s3://%s", *bucketName)
+	}
+
+	if *storageCheck && store == "" {
+		return nil, fmt.Errorf("-storage is required with -storage-check")
+	}
+
+	// Amazon Transcribe can only read media from, and write results to, Amazon S3
+	// itself — it has no notion of the local filesystem, GCS, or a third-party
+	// S3-compatible endpoint. So outside of streaming mode and -storage-check, the
+	// storage backend must be the same real S3 bucket used as -b, or a job started
+	// against it will simply never find its input.
+	if !*stream && !*storageCheck {
+		u, err := url.Parse(store)
+		if err != nil {
+			return nil, fmt.Errorf("parse storage URL %q: %w", store, err)
+		}
+		if u.Scheme != "s3" {
+			return nil, fmt.Errorf("storage backend %q: Amazon Transcribe requires media and results to live in Amazon S3; use an s3:// URL (file://, gs://, and generic S3-compatible endpoints are not reachable by the Transcribe service)", store)
+		}
+		if u.Host != *bucketName {
+			return nil, fmt.Errorf("storage backend %q: bucket must match -b %q", store, *bucketName)
+		}
+	}
+
+	// The vocabulary filter's remote name is also derived from -vocabulary-name
+	// (see EnsureVocabularyFilter), so it's required here too even without
+	// -vocabulary-file.
+	if (*vocabularyFile != "" || *vocabFilterFile != "") && *vocabularyName == "" {
+		return nil, fmt.Errorf("-vocabulary-name is required with -vocabulary-file or -vocab-filter-file")
 	}
 
-	if !isValid {
-		return nil, fmt.Errorf("invalid bucket name %q", *bucketName)
+	switch strings.ToLower(*vocabFilterMethod) {
+	case "mask", "remove", "tag":
+	default:
+		return nil, fmt.Errorf("invalid vocabulary filter method %q: must be one of mask|remove|tag", *vocabFilterMethod)
 	}
 
 	return &types.AppConfig{
@@ -64,6 +153,23 @@ func New(args []string) (*types.AppConfig, error) {
 		LanguageCode:       *languageCode,
 		SpeakerDiarization: *speakerDiarization,
 		MaxSpeakers:        *maxSpeakers,
+		OutputFormat:       format,
+		Stream:             *stream,
+		StorageURL:         store,
+		StorageCheck:       *storageCheck,
+		TranscodeMode:      transcodeMode,
+		SampleRate:         *sampleRate,
+		VocabularyFile:     *vocabularyFile,
+		VocabularyName:     *vocabularyName,
+		VocabFilterFile:    *vocabFilterFile,
+		VocabFilterMethod:  strings.ToLower(*vocabFilterMethod),
+		OutputDir:          *outputDir,
+		Concurrency:        *concurrency,
+		ManifestPath:       *manifestPath,
+		// defaults chosen for subtitle readability; not yet flag-configurable
+		MaxCueDuration:       5 * time.Second,
+		MaxCueChars:          80,
+		SplitOnSpeakerChange: true,
 	}, nil
 }
 
@@ -7,8 +7,20 @@ import (
 	"github.com/embano1/transcribe-meetings/internal/types"
 )
 
-// FormatTranscriptWithSpeakers formats the transcript with speaker labels for better readability
+// SpeakerPrefix renders a Transcribe speaker label (e.g. "spk_0") as a
+// human-readable line prefix (e.g. "Speaker 0: ").
+func SpeakerPrefix(label string) string {
+	return fmt.Sprintf("Speaker %s: ", strings.TrimPrefix(label, "spk_"))
+}
+
+// FormatTranscriptWithSpeakers formats the transcript with speaker labels for better readability.
 func FormatTranscriptWithSpeakers(result *types.TranscriptionResult) string {
+	// Transcribe's batch JSON only records speaker labels on
+	// results.speaker_labels.segments, not on the items themselves (item.SpeakerLabel
+	// is never populated), so this looks up each item's speaker the same way
+	// StreamCues does.
+	speakerByStart := speakerStartTimes(result.Results.SpeakerLabels)
+
 	var formatted strings.Builder
 	currentSpeaker := ""
 
@@ -22,8 +34,9 @@ func FormatTranscriptWithSpeakers(result *types.TranscriptionResult) string {
 			}
 		case "pronunciation":
 			// Check if speaker has changed
-			if item.SpeakerLabel != "" && item.SpeakerLabel != currentSpeaker {
-				currentSpeaker = item.SpeakerLabel
+			speaker := speakerByStart[item.StartTime]
+			if speaker != "" && speaker != currentSpeaker {
+				currentSpeaker = speaker
 				// Add a new line for new speaker (except for the first speaker)
 				if formatted.Len() > 0 {
 					formatted.WriteString("\n\n")
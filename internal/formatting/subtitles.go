@@ -0,0 +1,345 @@
+package formatting
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/embano1/transcribe-meetings/internal/types"
+)
+
+// Cue represents a single subtitle cue with a time range and rendered text.
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// StreamCues decodes a Transcribe result JSON from dec, grouping its
+// pronunciation items into subtitle cues bounded by punctuation, a maximum
+// duration/character count, and speaker-label changes, and invokes emit as soon
+// as a cue closes. results.items is consumed one element at a time rather than
+// decoded in full, so memory stays flat regardless of meeting length. It relies
+// on results.speaker_labels appearing before results.items in the document,
+// which holds for Transcribe's own batch output.
+func StreamCues(dec *json.Decoder, cfg *types.AppConfig, emit func(Cue) error) (int, error) {
+	acc := newCueAccumulator(cfg)
+	var speakerByStart map[string]string
+	count := 0
+
+	emitIfFlushed := func(cue Cue, flushed bool) error {
+		if !flushed {
+			return nil
+		}
+		count++
+		return emit(cue)
+	}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, fmt.Errorf("decode result: %w", err)
+	}
+	for dec.More() {
+		key, err := decodeKey(dec)
+		if err != nil {
+			return 0, fmt.Errorf("decode result: %w", err)
+		}
+		if key != "results" {
+			if err := skipValue(dec); err != nil {
+				return 0, fmt.Errorf("skip %q: %w", key, err)
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '{'); err != nil {
+			return 0, fmt.Errorf("decode results: %w", err)
+		}
+		for dec.More() {
+			innerKey, err := decodeKey(dec)
+			if err != nil {
+				return 0, fmt.Errorf("decode results: %w", err)
+			}
+			switch innerKey {
+			case "speaker_labels":
+				var labels types.SpeakerLabels
+				if err := dec.Decode(&labels); err != nil {
+					return 0, fmt.Errorf("decode results.speaker_labels: %w", err)
+				}
+				speakerByStart = speakerStartTimes(&labels)
+			case "items":
+				if err := expectDelim(dec, '['); err != nil {
+					return 0, fmt.Errorf("decode results.items: %w", err)
+				}
+				for dec.More() {
+					var item types.Item
+					if err := dec.Decode(&item); err != nil {
+						return 0, fmt.Errorf("decode results.items: %w", err)
+					}
+					if err := emitIfFlushed(acc.add(item, speakerByStart)); err != nil {
+						return 0, err
+					}
+				}
+				if _, err := dec.Token(); err != nil { // closing ']'
+					return 0, fmt.Errorf("decode results.items: %w", err)
+				}
+			default:
+				if err := skipValue(dec); err != nil {
+					return 0, fmt.Errorf("skip results.%s: %w", innerKey, err)
+				}
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing '}' of results
+			return 0, fmt.Errorf("decode results: %w", err)
+		}
+	}
+	if _, err := dec.Token(); err != nil { // closing '}' of the top-level object
+		return 0, fmt.Errorf("decode result: %w", err)
+	}
+
+	if err := emitIfFlushed(acc.flush()); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// cueAccumulator holds the in-progress cue-grouping state shared by StreamCues
+// as it consumes items one at a time. A cue ends on sentence-ending
+// punctuation, a speaker change (if cfg.SplitOnSpeakerChange is set), or once
+// cfg.MaxCueDuration/cfg.MaxCueChars would be exceeded by the next word.
+type cueAccumulator struct {
+	cfg        *types.AppConfig
+	text       strings.Builder
+	start, end time.Duration
+	haveStart  bool
+	speaker    string
+}
+
+func newCueAccumulator(cfg *types.AppConfig) *cueAccumulator {
+	return &cueAccumulator{cfg: cfg}
+}
+
+// add folds item into the current cue, flushing it first if warranted, and
+// reports the flushed cue, if any.
+func (a *cueAccumulator) add(item types.Item, speakerByStart map[string]string) (Cue, bool) {
+	if len(item.Alternatives) == 0 {
+		return Cue{}, false
+	}
+	content := item.Alternatives[0].Content
+
+	if item.Type == "punctuation" {
+		a.text.WriteString(content)
+		if content == "." || content == "!" || content == "?" {
+			return a.flush()
+		}
+		return Cue{}, false
+	}
+	if item.Type != "pronunciation" {
+		return Cue{}, false
+	}
+
+	itemStart, err := parseSeconds(item.StartTime)
+	if err != nil {
+		return Cue{}, false
+	}
+	itemEnd, err := parseSeconds(item.EndTime)
+	if err != nil {
+		return Cue{}, false
+	}
+
+	itemSpeaker := speakerByStart[item.StartTime]
+	speakerChanged := a.cfg.SplitOnSpeakerChange && a.speaker != "" && itemSpeaker != "" && itemSpeaker != a.speaker
+	if itemSpeaker != "" {
+		a.speaker = itemSpeaker
+	}
+
+	overDuration := a.haveStart && a.cfg.MaxCueDuration > 0 && itemEnd-a.start > a.cfg.MaxCueDuration
+	overChars := a.cfg.MaxCueChars > 0 && a.text.Len()+len(content)+1 > a.cfg.MaxCueChars
+
+	var flushed Cue
+	var ok bool
+	if speakerChanged || overDuration || overChars {
+		flushed, ok = a.flush()
+	}
+
+	if !a.haveStart {
+		a.start = itemStart
+		a.haveStart = true
+	}
+	a.end = itemEnd
+
+	if a.text.Len() > 0 && !strings.HasSuffix(a.text.String(), " ") {
+		a.text.WriteString(" ")
+	}
+	a.text.WriteString(content)
+
+	return flushed, ok
+}
+
+// flush closes out the current cue, if any text has accumulated for it.
+func (a *cueAccumulator) flush() (Cue, bool) {
+	if a.text.Len() == 0 {
+		return Cue{}, false
+	}
+	cue := Cue{Start: a.start, End: a.end, Text: a.text.String()}
+	a.text.Reset()
+	a.haveStart = false
+	return cue, true
+}
+
+// parseSeconds converts a Transcribe "12.345" style timestamp into a Duration.
+func parseSeconds(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty timestamp")
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse timestamp %q: %w", s, err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// speakerStartTimes maps each pronunciation item's start_time to the speaker
+// label of the segment it belongs to. Transcribe's batch JSON only records
+// speaker labels on results.speaker_labels.segments, not on the items
+// themselves, so this lookup is how cueAccumulator attributes a speaker to an
+// item.
+func speakerStartTimes(labels *types.SpeakerLabels) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, segment := range labels.Segments {
+		for _, item := range segment.Items {
+			m[item.StartTime] = segment.SpeakerLabel
+		}
+	}
+	return m
+}
+
+// expectDelim reads the next token from dec and requires it to be the given
+// JSON delimiter ('{', '}', '[', or ']').
+func expectDelim(dec *json.Decoder, want rune) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || rune(delim) != want {
+		return fmt.Errorf("expected %q, got %v", string(want), tok)
+	}
+	return nil
+}
+
+// decodeKey reads the next token from dec and requires it to be an object key.
+func decodeKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+// skipValue consumes and discards the next complete JSON value from dec,
+// whether it's a scalar or a (possibly nested) object or array. It's how
+// StreamCues ignores result fields it has no use for (e.g. transcripts,
+// status) without buffering them.
+func skipValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		return nil // scalar, already consumed
+	}
+	depth := 1
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+// SRTWriter renders cues as SubRip (.srt) subtitles, writing each one to the
+// underlying writer as it is produced.
+type SRTWriter struct {
+	bw    *bufio.Writer
+	index int
+}
+
+// NewSRTWriter creates an SRTWriter over w.
+func NewSRTWriter(w io.Writer) *SRTWriter {
+	return &SRTWriter{bw: bufio.NewWriter(w)}
+}
+
+// WriteCue renders cue and flushes it to the underlying writer.
+func (s *SRTWriter) WriteCue(cue Cue) error {
+	s.index++
+	if _, err := fmt.Fprintf(s.bw, "%d\n%s --> %s\n%s\n\n", s.index, srtTimestamp(cue.Start), srtTimestamp(cue.End), cue.Text); err != nil {
+		return fmt.Errorf("write SRT cue %d: %w", s.index, err)
+	}
+	return s.bw.Flush()
+}
+
+// WebVTTWriter renders cues as WebVTT (.vtt) subtitles, writing each one to the
+// underlying writer as it is produced.
+type WebVTTWriter struct {
+	bw *bufio.Writer
+}
+
+// NewWebVTTWriter creates a WebVTTWriter over w, writing the WebVTT header
+// immediately.
+func NewWebVTTWriter(w io.Writer) (*WebVTTWriter, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := fmt.Fprint(bw, "WEBVTT\n\n"); err != nil {
+		return nil, fmt.Errorf("write WebVTT header: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, fmt.Errorf("write WebVTT header: %w", err)
+	}
+	return &WebVTTWriter{bw: bw}, nil
+}
+
+// WriteCue renders cue and flushes it to the underlying writer.
+func (v *WebVTTWriter) WriteCue(cue Cue) error {
+	if _, err := fmt.Fprintf(v.bw, "%s --> %s\n%s\n\n", webVTTTimestamp(cue.Start), webVTTTimestamp(cue.End), cue.Text); err != nil {
+		return fmt.Errorf("write WebVTT cue: %w", err)
+	}
+	return v.bw.Flush()
+}
+
+func srtTimestamp(d time.Duration) string {
+	return formatTimestamp(d, ",")
+}
+
+func webVTTTimestamp(d time.Duration) string {
+	return formatTimestamp(d, ".")
+}
+
+func formatTimestamp(d time.Duration, millisSep string) string {
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+	d -= s * time.Second
+	ms := d / time.Millisecond
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", h, m, s, millisSep, ms)
+}
@@ -0,0 +1,100 @@
+// Package media detects and (optionally) transcodes audio/video containers so
+// they can be handed to Amazon Transcribe in a supported MediaFormat.
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Format identifies a detected media container/codec.
+type Format string
+
+const (
+	FormatM4A  Format = "m4a"
+	FormatMP3  Format = "mp3"
+	FormatWAV  Format = "wav"
+	FormatFLAC Format = "flac"
+	FormatMP4  Format = "mp4"
+	FormatWebM Format = "webm"
+	FormatOgg  Format = "ogg"
+	// FormatAAC is a raw ADTS AAC bitstream (e.g. a ".aac" file), which
+	// Transcribe does not accept natively and must be transcoded.
+	FormatAAC     Format = "aac"
+	FormatUnknown Format = ""
+
+	// FormatPCM is never returned by Sniff; it is a Transcode target only, used to
+	// produce the raw headerless 16-bit signed little-endian PCM that Amazon
+	// Transcribe Streaming's MediaEncodingPcm requires.
+	FormatPCM Format = "pcm"
+)
+
+// TranscribeSupported lists the media formats Amazon Transcribe accepts natively,
+// without needing to be transcoded first.
+var TranscribeSupported = map[Format]bool{
+	FormatM4A:  true,
+	FormatMP3:  true,
+	FormatWAV:  true,
+	FormatFLAC: true,
+	FormatMP4:  true,
+	FormatWebM: true,
+	FormatOgg:  true,
+}
+
+// Sniff detects the media format of the file at path by inspecting its magic
+// bytes.
+func Sniff(path string) (Format, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return FormatUnknown, fmt.Errorf("open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, 16)
+	n, err := f.Read(header)
+	if err != nil && err != io.EOF {
+		return FormatUnknown, fmt.Errorf("read header of %q: %w", path, err)
+	}
+	header = header[:n]
+
+	format := detect(header)
+	if format == FormatUnknown {
+		return FormatUnknown, fmt.Errorf("unrecognized media format for %q", path)
+	}
+	return format, nil
+}
+
+// detect inspects a file's leading bytes and reports the matching Format, or
+// FormatUnknown if none match.
+func detect(header []byte) Format {
+	switch {
+	case bytes.HasPrefix(header, []byte("fLaC")):
+		return FormatFLAC
+	case len(header) >= 12 && bytes.HasPrefix(header, []byte("RIFF")) && bytes.Equal(header[8:12], []byte("WAVE")):
+		return FormatWAV
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return FormatOgg
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return FormatWebM
+	case len(header) >= 12 && bytes.Equal(header[4:8], []byte("ftyp")):
+		if strings.HasPrefix(string(header[8:12]), "M4A") {
+			return FormatM4A
+		}
+		return FormatMP4
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return FormatMP3
+	// An ADTS AAC header is a 12-bit syncword followed by a layer field of 00
+	// (header[1]&0xF6 == 0xF0). MP3 frames share the leading sync bits but carry a
+	// non-zero layer, and the MP3 check below (header[1]&0xE0 == 0xE0) would also
+	// match ADTS, so AAC must be checked first.
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xF6 == 0xF0:
+		return FormatAAC
+	case len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		return FormatMP3
+	default:
+		return FormatUnknown
+	}
+}
@@ -0,0 +1,107 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+)
+
+// TranscodeMode controls whether unsupported input files are transcoded before
+// upload.
+type TranscodeMode string
+
+const (
+	// TranscodeAuto transcodes only formats Transcribe does not accept natively.
+	TranscodeAuto TranscodeMode = "auto"
+	// TranscodeNever never transcodes, even if the detected format is unsupported.
+	TranscodeNever TranscodeMode = "never"
+	// TranscodeAlways always transcodes, even if the detected format is supported.
+	TranscodeAlways TranscodeMode = "always"
+)
+
+// Transcode converts the audio at inputPath to targetFormat at sampleRate by
+// shelling out to ffmpeg. The ffmpeg process streams its output directly to the
+// returned io.ReadCloser, so callers can pipe it straight into an upload without a
+// full temp copy of large meeting recordings. Closing the reader waits for ffmpeg
+// to exit and surfaces any failure.
+func Transcode(ctx context.Context, inputPath string, targetFormat Format, sampleRate int) (io.ReadCloser, error) {
+	return runFFmpeg(ctx, []string{"-i", inputPath}, targetFormat, sampleRate, nil)
+}
+
+// TranscodePCM converts the audio read from r to raw headerless 16-bit signed
+// little-endian PCM at sampleRate by piping it through ffmpeg, for callers (such
+// as streaming transcription) that have an io.Reader rather than a file path.
+func TranscodePCM(ctx context.Context, r io.Reader, sampleRate int) (io.ReadCloser, error) {
+	return runFFmpeg(ctx, []string{"-i", "pipe:0"}, FormatPCM, sampleRate, r)
+}
+
+// runFFmpeg starts ffmpeg with inputArgs plus the codec/muxer args for
+// targetFormat at sampleRate, optionally piping stdin from stdin, and returns its
+// stdout as a ReadCloser that waits for the process to exit on Close.
+func runFFmpeg(ctx context.Context, inputArgs []string, targetFormat Format, sampleRate int, stdin io.Reader) (io.ReadCloser, error) {
+	codecArgs, err := ffmpegCodecArgs(targetFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append(append([]string{}, inputArgs...), "-ar", strconv.Itoa(sampleRate))
+	args = append(args, codecArgs...)
+	args = append(args, "-f", ffmpegMuxerName(targetFormat), "pipe:1")
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stdin = stdin
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("attach ffmpeg stdout: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start ffmpeg: %w", err)
+	}
+
+	return &transcodeReader{ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// transcodeReader wraps ffmpeg's stdout pipe and waits for the process to exit on
+// Close, surfacing any ffmpeg failure.
+type transcodeReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (t *transcodeReader) Close() error {
+	closeErr := t.ReadCloser.Close()
+	if err := t.cmd.Wait(); err != nil {
+		return fmt.Errorf("ffmpeg: %w", err)
+	}
+	return closeErr
+}
+
+func ffmpegCodecArgs(format Format) ([]string, error) {
+	switch format {
+	case FormatM4A:
+		return []string{"-c:a", "aac"}, nil
+	case FormatMP3:
+		return []string{"-c:a", "libmp3lame"}, nil
+	case FormatWAV:
+		return []string{"-c:a", "pcm_s16le"}, nil
+	case FormatFLAC:
+		return []string{"-c:a", "flac"}, nil
+	case FormatPCM:
+		return []string{"-c:a", "pcm_s16le", "-ac", "1"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported transcode target format %q", format)
+	}
+}
+
+// ffmpegMuxerName maps a transcode target to the ffmpeg -f muxer name. It differs
+// from the Format string only for FormatPCM, which muxes as raw "s16le" rather
+// than a container named "pcm".
+func ffmpegMuxerName(format Format) string {
+	if format == FormatPCM {
+		return "s16le"
+	}
+	return string(format)
+}
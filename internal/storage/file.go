@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileStore is an ObjectStore backed by a directory on the local filesystem, keyed
+// by a path relative to root. It is primarily useful for running against a fake
+// backend in tests without hitting real cloud storage.
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a FileStore rooted at root, creating the directory if it
+// does not already exist.
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage root %q: %w", root, err)
+	}
+	return &FileStore{root: root}, nil
+}
+
+// Head verifies that root exists and is a directory.
+func (f *FileStore) Head(ctx context.Context) error {
+	info, err := os.Stat(f.root)
+	if err != nil {
+		return fmt.Errorf("stat storage root %q: %w", f.root, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("storage root %q is not a directory", f.root)
+	}
+	return nil
+}
+
+// Exists reports whether key is present under root.
+func (f *FileStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := os.Stat(f.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Put writes the contents of r to key, creating parent directories as needed.
+func (f *FileStore) Put(ctx context.Context, key string, r io.Reader) error {
+	dst := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("create parent directory for %q: %w", key, err)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("create object %q: %w", key, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, r); err != nil {
+		return fmt.Errorf("write object %q: %w", key, err)
+	}
+	return nil
+}
+
+// Get opens key for reading. Callers must close the returned reader.
+func (f *FileStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(f.path(key))
+	if err != nil {
+		return nil, fmt.Errorf("open object %q: %w", key, err)
+	}
+	return file, nil
+}
+
+func (f *FileStore) path(key string) string {
+	return filepath.Join(f.root, filepath.FromSlash(key))
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSStore is an ObjectStore backed by a Google Cloud Storage bucket.
+type GCSStore struct {
+	client *storage.Client
+	bucket string
+}
+
+// NewGCSStore creates a GCSStore for bucket using application default credentials.
+func NewGCSStore(ctx context.Context, bucket string) (*GCSStore, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create GCS client: %w", err)
+	}
+	return &GCSStore{client: client, bucket: bucket}, nil
+}
+
+// Head verifies that the configured bucket exists and is accessible.
+func (g *GCSStore) Head(ctx context.Context) error {
+	if _, err := g.client.Bucket(g.bucket).Attrs(ctx); err != nil {
+		return fmt.Errorf("verify GCS bucket %q: %w", g.bucket, err)
+	}
+	return nil
+}
+
+// Exists reports whether key is present in the bucket.
+func (g *GCSStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := g.client.Bucket(g.bucket).Object(key).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Put uploads the contents of r to key.
+func (g *GCSStore) Put(ctx context.Context, key string, r io.Reader) error {
+	w := g.client.Bucket(g.bucket).Object(key).NewWriter(ctx)
+	if _, err := io.Copy(w, r); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("write object %q: %w", key, err)
+	}
+	return w.Close()
+}
+
+// Get opens key for reading. Callers must close the returned reader.
+func (g *GCSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := g.client.Bucket(g.bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open object %q: %w", key, err)
+	}
+	return r, nil
+}
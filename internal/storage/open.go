@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Open resolves rawURL to an ObjectStore. Supported schemes are s3:// (Amazon S3),
+// gs:// (Google Cloud Storage), file:// (local filesystem), and http(s):// (any
+// S3-compatible endpoint, e.g. MinIO or LocalStack, addressed path-style).
+func Open(ctx context.Context, rawURL, region string) (ObjectStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse storage URL %q: %w", rawURL, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "s3":
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+		if err != nil {
+			return nil, fmt.Errorf("load AWS SDK config: %w", err)
+		}
+		return NewS3Store(s3.NewFromConfig(awsCfg), u.Host), nil
+	case "gs":
+		return NewGCSStore(ctx, u.Host)
+	case "file":
+		root := u.Path
+		if root == "" {
+			root = u.Opaque
+		}
+		return NewFileStore(root)
+	case "http", "https":
+		bucket := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)[0]
+		if bucket == "" {
+			return nil, fmt.Errorf("storage URL %q missing bucket path", rawURL)
+		}
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+		if err != nil {
+			return nil, fmt.Errorf("load AWS SDK config: %w", err)
+		}
+		endpoint := fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+		return NewS3CompatibleStore(awsCfg, endpoint, bucket), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage scheme %q in %q", u.Scheme, rawURL)
+	}
+}
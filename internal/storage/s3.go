@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+// S3Store is an ObjectStore backed by Amazon S3 or any S3-compatible endpoint (e.g.
+// MinIO), selected via NewS3CompatibleStore's custom endpoint and path-style
+// addressing.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Store creates an S3Store for bucket using client.
+func NewS3Store(client *s3.Client, bucket string) *S3Store {
+	return &S3Store{client: client, bucket: bucket}
+}
+
+// NewS3CompatibleStore creates an S3Store pointed at a custom S3-compatible
+// endpoint, such as MinIO or LocalStack, using path-style addressing.
+func NewS3CompatibleStore(awsCfg aws.Config, endpoint, bucket string) *S3Store {
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.BaseEndpoint = &endpoint
+		o.UsePathStyle = true
+	})
+	return &S3Store{client: client, bucket: bucket}
+}
+
+// Head checks that the configured bucket exists and is accessible.
+func (s *S3Store) Head(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &s.bucket})
+	return err
+}
+
+// Exists uses HeadObject to determine if the object already exists.
+func (s *S3Store) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: &s.bucket, Key: &key})
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Put uploads the contents of r to key.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{Bucket: &s.bucket, Key: &key, Body: r})
+	return err
+}
+
+// Get opens key for reading. Callers must close the returned reader.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: &s.bucket, Key: &key})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// isNotFoundError determines if an error from AWS indicates a "not found" condition.
+func isNotFoundError(err error) bool {
+	var apiErr smithy.APIError
+	if err == nil {
+		return false
+	}
+	if errors.As(err, &apiErr) {
+		if apiErr.ErrorCode() == "NotFoundException" || apiErr.ErrorCode() == "404" {
+			return true
+		}
+	}
+	return strings.Contains(err.Error(), "NotFound:")
+}
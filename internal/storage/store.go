@@ -0,0 +1,29 @@
+// Package storage provides a pluggable object storage abstraction so the
+// application can address Amazon S3, S3-compatible endpoints (e.g. MinIO), local
+// disk, or Google Cloud Storage through a single interface. Note that the
+// transcription pipeline itself only works against the s3:// backend: Amazon
+// Transcribe reads media from, and writes results to, Amazon S3 exclusively, so
+// internal/config rejects the other schemes outside of streaming mode and
+// -storage-check. -storage-check is the reachable entrypoint for the other
+// backends: it uploads and reads back the input file against whichever backend
+// is configured, without starting a Transcribe job, which is how a LocalStack or
+// MinIO setup gets exercised from the CLI.
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// ObjectStore is implemented by each supported backend.
+type ObjectStore interface {
+	// Head verifies the backing store is reachable and the configured
+	// bucket/container/root exists.
+	Head(ctx context.Context) error
+	// Exists reports whether an object exists at key.
+	Exists(ctx context.Context, key string) (bool, error)
+	// Put uploads the contents of r to key.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get opens the object at key for reading. Callers must close the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
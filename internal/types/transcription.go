@@ -1,5 +1,25 @@
 package types
 
+import (
+	"time"
+
+	"github.com/embano1/transcribe-meetings/internal/media"
+)
+
+// OutputFormat identifies how a transcript should be rendered to the output file.
+type OutputFormat string
+
+const (
+	// FormatText renders a plain text transcript (optionally speaker-diarized).
+	FormatText OutputFormat = "txt"
+	// FormatSRT renders the transcript as SubRip (.srt) subtitles.
+	FormatSRT OutputFormat = "srt"
+	// FormatWebVTT renders the transcript as WebVTT (.vtt) subtitles.
+	FormatWebVTT OutputFormat = "vtt"
+	// FormatJSON renders the decoded Transcribe result as indented JSON.
+	FormatJSON OutputFormat = "json"
+)
+
 // TranscriptionResult represents the JSON structure returned by Transcribe.
 type TranscriptionResult struct {
 	Results struct {
@@ -51,4 +71,59 @@ type AppConfig struct {
 	SpeakerDiarization bool
 	MaxSpeakers        int
 	Force              bool
+
+	// OutputFormat selects the transcript rendering: txt, srt, vtt, or json.
+	OutputFormat OutputFormat
+	// MaxCueDuration bounds how long a single subtitle cue may span.
+	MaxCueDuration time.Duration
+	// MaxCueChars bounds how many characters a single subtitle cue may contain.
+	MaxCueChars int
+	// SplitOnSpeakerChange starts a new subtitle cue whenever the speaker label changes.
+	SplitOnSpeakerChange bool
+
+	// Stream enables near-real-time transcription via Amazon Transcribe Streaming,
+	// bypassing the S3 upload and batch job polling.
+	Stream bool
+
+	// StorageURL selects the object store used for the input upload, e.g.
+	// "s3://bucket", "file:///path", "gs://bucket", or "http(s)://minio-host/bucket".
+	StorageURL string
+	// StorageCheck, when set, uploads InputFilePath to StorageURL, reads it back,
+	// and exits without running Transcribe. It's the only way to exercise the
+	// file://, gs://, and S3-compatible backends end-to-end, since Transcribe
+	// itself can only read media from Amazon S3.
+	StorageCheck bool
+
+	// TranscodeMode controls whether an input file with an unsupported container
+	// is transcoded before upload.
+	TranscodeMode media.TranscodeMode
+	// SampleRate is the target audio sample rate (Hz) used when transcoding.
+	SampleRate int
+	// MediaFormat is the Transcribe MediaFormat of the uploaded file, either
+	// detected from the input or set to the transcode target.
+	MediaFormat media.Format
+
+	// VocabularyFile is the path to a custom vocabulary file to use for this job.
+	VocabularyFile string
+	// VocabularyName is the base name for the vocabulary; the actual remote
+	// vocabulary is named "<VocabularyName>-<hash of VocabularyFile>".
+	VocabularyName string
+	// VocabFilterFile is the path to a vocabulary filter file to use for this job.
+	VocabFilterFile string
+	// VocabFilterMethod controls how filtered words are handled: mask, remove, or tag.
+	VocabFilterMethod string
+
+	// ResolvedVocabularyName is the actual remote vocabulary name after
+	// EnsureVocabulary has run, for use in StartTranscriptionJobInput.Settings.
+	ResolvedVocabularyName string
+	// ResolvedVocabularyFilterName is the actual remote vocabulary filter name
+	// after EnsureVocabularyFilter has run.
+	ResolvedVocabularyFilterName string
+
+	// OutputDir is the directory transcripts are written to in batch mode.
+	OutputDir string
+	// Concurrency is the number of recordings processed concurrently in batch mode.
+	Concurrency int
+	// ManifestPath, if set, is where a JSON summary of a batch run is written.
+	ManifestPath string
 }
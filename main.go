@@ -11,13 +11,19 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/transcribe"
+	"github.com/aws/aws-sdk-go-v2/service/transcribestreaming"
 
 	"github.com/embano1/transcribe-meetings/internal/aws"
+	"github.com/embano1/transcribe-meetings/internal/batch"
 	appConfig "github.com/embano1/transcribe-meetings/internal/config"
+	"github.com/embano1/transcribe-meetings/internal/formatting"
+	"github.com/embano1/transcribe-meetings/internal/media"
+	"github.com/embano1/transcribe-meetings/internal/storage"
+	"github.com/embano1/transcribe-meetings/internal/types"
 )
 
 func main() {
@@ -40,66 +46,326 @@ func run(ctx context.Context, args []string) error {
 		return fmt.Errorf("load AWS SDK config: %w", err)
 	}
 
-	// fail fast if the client is not authorized
-	s3Client := s3.NewFromConfig(awsCfg)
-	s3Service := aws.NewS3Service(s3Client)
-	err = s3Service.HeadBucket(ctx, cfgApp.BucketName)
+	if cfgApp.Stream {
+		streamingClient := transcribestreaming.NewFromConfig(awsCfg)
+		streamingService := aws.NewStreamingTranscribeService(streamingClient)
+		return runStreaming(ctx, streamingService, cfgApp)
+	}
+
+	// fail fast if the backend is not reachable
+	store, err := storage.Open(ctx, cfgApp.StorageURL, cfgApp.Region)
 	if err != nil {
-		return fmt.Errorf("verify bucket %q exists: %w", cfgApp.BucketName, err)
+		return fmt.Errorf("open storage backend %q: %w", cfgApp.StorageURL, err)
+	}
+	if err := store.Head(ctx); err != nil {
+		return fmt.Errorf("verify storage backend %q: %w", cfgApp.StorageURL, err)
 	}
 
-	f, err := os.Open(cfgApp.InputFilePath)
+	if cfgApp.StorageCheck {
+		return runStorageCheck(ctx, store, cfgApp)
+	}
+
+	transcribeClient := transcribe.NewFromConfig(awsCfg)
+	vocabularyService := aws.NewVocabularyService(transcribeClient)
+
+	// Resolve vocabulary/filter once, up front: cfgApp is shared by every worker
+	// in batch.Run's pool, and EnsureVocabulary/EnsureVocabularyFilter are
+	// check-then-act against the same hashed remote name, so calling them from
+	// each worker would race to create it.
+	if cfgApp.VocabularyFile != "" {
+		vocabName, err := vocabularyService.EnsureVocabulary(ctx, cfgApp.VocabularyName, cfgApp.VocabularyFile, cfgApp.LanguageCode)
+		if err != nil {
+			return fmt.Errorf("ensure vocabulary: %w", err)
+		}
+		cfgApp.ResolvedVocabularyName = vocabName
+	}
+	if cfgApp.VocabFilterFile != "" {
+		filterName, err := vocabularyService.EnsureVocabularyFilter(ctx, cfgApp.VocabularyName, cfgApp.VocabFilterFile, cfgApp.LanguageCode)
+		if err != nil {
+			return fmt.Errorf("ensure vocabulary filter: %w", err)
+		}
+		cfgApp.ResolvedVocabularyFilterName = filterName
+	}
+
+	p := &pipeline{
+		store:             store,
+		transcribeService: aws.NewTranscribeService(transcribeClient),
+		cfg:               cfgApp,
+	}
+
+	if batch.IsBatch(cfgApp.InputFilePath) {
+		return runBatch(ctx, p, cfgApp)
+	}
+
+	job := batch.Job{InputPath: cfgApp.InputFilePath, OutputPath: cfgApp.OutputFilePath}
+	if _, err := p.run(ctx, job); err != nil {
+		return err
+	}
+	return nil
+}
+
+// runBatch discovers the recordings matched by cfgApp.InputFilePath and runs p
+// over them concurrently, writing one transcript per recording into
+// cfgApp.OutputDir.
+func runBatch(ctx context.Context, p *pipeline, cfgApp *types.AppConfig) error {
+	inputs, err := batch.Discover(cfgApp.InputFilePath)
 	if err != nil {
-		return fmt.Errorf("open input file: %w", err)
+		return fmt.Errorf("discover input files: %w", err)
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("no recordings found matching %q", cfgApp.InputFilePath)
+	}
+
+	if err := os.MkdirAll(cfgApp.OutputDir, 0o755); err != nil {
+		return fmt.Errorf("create output directory %q: %w", cfgApp.OutputDir, err)
+	}
+
+	jobs := make([]batch.Job, len(inputs))
+	ext := outputExtension(cfgApp.OutputFormat)
+	for i, input := range inputs {
+		base := strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+		jobs[i] = batch.Job{
+			InputPath:  input,
+			OutputPath: filepath.Join(cfgApp.OutputDir, base+ext),
+		}
+	}
+
+	results := batch.Run(ctx, jobs, cfgApp.Concurrency, p.run)
+	batch.Summarize(results)
+
+	if cfgApp.ManifestPath != "" {
+		if err := batch.WriteManifest(cfgApp.ManifestPath, batch.NewManifest(results)); err != nil {
+			return fmt.Errorf("write manifest: %w", err)
+		}
+		log.Printf("Manifest written to %q", cfgApp.ManifestPath)
+	}
+
+	if failed := countFailed(results); failed > 0 {
+		return fmt.Errorf("%d of %d recordings failed", failed, len(results))
+	}
+	return nil
+}
+
+func countFailed(results []batch.Result) int {
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// outputExtension maps an output format to its file extension, including the
+// leading dot.
+func outputExtension(format types.OutputFormat) string {
+	switch format {
+	case types.FormatSRT:
+		return ".srt"
+	case types.FormatWebVTT:
+		return ".vtt"
+	case types.FormatJSON:
+		return ".json"
+	default:
+		return ".txt"
+	}
+}
+
+// pipeline is the upload/transcode/transcribe/fetch sequence for a single
+// recording, shared by single-file mode and batch mode.
+type pipeline struct {
+	store             storage.ObjectStore
+	transcribeService *aws.TranscribeService
+	cfg               *types.AppConfig
+}
+
+// run processes job.InputPath end-to-end and writes the transcript to
+// job.OutputPath, returning job annotated with the derived job name and S3 key.
+func (p *pipeline) run(ctx context.Context, job batch.Job) (batch.Job, error) {
+	// Copy the shared config so concurrent batch workers don't race on the
+	// per-job fields (MediaFormat) set below. Vocabulary/filter resolution
+	// already happened once in run(), before the worker pool started.
+	cfg := *p.cfg
+	cfg.InputFilePath = job.InputPath
+	cfg.OutputFilePath = job.OutputPath
+
+	f, err := os.Open(cfg.InputFilePath)
+	if err != nil {
+		return job, fmt.Errorf("open input file: %w", err)
 	}
 	hasher := sha256.New()
 	if _, err := io.Copy(hasher, f); err != nil {
-		return fmt.Errorf("compute file hash: %w", err)
+		f.Close()
+		return job, fmt.Errorf("compute file hash: %w", err)
 	}
 	f.Close()
 
 	fileHash := hex.EncodeToString(hasher.Sum(nil))[:16] // using first 16 hex digits
 
-	// Use the file hash and original file name to form an S3 key and job name.
-	fileName := filepath.Base(cfgApp.InputFilePath)
-	s3Key := fmt.Sprintf("uploads/%s_%s", fileHash, fileName)
-	jobName := fmt.Sprintf("transcribe-%s", fileHash)
+	// Use the file hash and original file name to form an object key and job name.
+	fileName := filepath.Base(cfg.InputFilePath)
+	job.S3Key = fmt.Sprintf("uploads/%s_%s", fileHash, fileName)
+	job.JobName = fmt.Sprintf("transcribe-%s", fileHash)
+
+	log.Printf("[%s] using object key: %s", cfg.InputFilePath, job.S3Key)
+	log.Printf("[%s] using transcription job name: %s", cfg.InputFilePath, job.JobName)
+
+	detectedFormat, err := media.Sniff(cfg.InputFilePath)
+	if err != nil {
+		return job, fmt.Errorf("detect media format: %w", err)
+	}
 
-	log.Printf("Using S3 key: %s", s3Key)
-	log.Printf("Using transcription job name: %s", jobName)
+	cfg.MediaFormat = detectedFormat
+	// FLAC is both a pipe-friendly ffmpeg muxer (no seeking required, unlike the
+	// MP4 family) and natively accepted by Transcribe, so it's a safe transcode
+	// target regardless of the input container.
+	transcodeTarget := media.FormatFLAC
+	needsTranscode := cfg.TranscodeMode == media.TranscodeAlways ||
+		(cfg.TranscodeMode == media.TranscodeAuto && !media.TranscribeSupported[detectedFormat])
 
-	exists, err := s3Service.CheckObjectExists(ctx, cfgApp.BucketName, s3Key)
+	exists, err := p.store.Exists(ctx, job.S3Key)
 	if err != nil {
-		return fmt.Errorf("check S3 object existence: %w", err)
+		return job, fmt.Errorf("check object existence: %w", err)
 	}
 	if exists {
-		log.Printf("File already exists in S3; skipping upload.")
+		log.Printf("[%s] file already exists in storage backend; skipping upload.", cfg.InputFilePath)
+		if needsTranscode {
+			cfg.MediaFormat = transcodeTarget
+		}
+	} else if needsTranscode {
+		log.Printf("[%s] transcoding %s to %s before upload...", cfg.InputFilePath, detectedFormat, transcodeTarget)
+		r, err := media.Transcode(ctx, cfg.InputFilePath, transcodeTarget, cfg.SampleRate)
+		if err != nil {
+			return job, fmt.Errorf("transcode input file: %w", err)
+		}
+		err = p.store.Put(ctx, job.S3Key, r)
+		r.Close()
+		if err != nil {
+			return job, fmt.Errorf("upload transcoded file: %w", err)
+		}
+		cfg.MediaFormat = transcodeTarget
+		log.Printf("[%s] upload completed.", cfg.InputFilePath)
 	} else {
-		log.Printf("Uploading file to S3...")
-		if err := s3Service.UploadFile(ctx, cfgApp.BucketName, s3Key, cfgApp.InputFilePath); err != nil {
-			return fmt.Errorf("upload file to S3: %w", err)
+		log.Printf("[%s] uploading file to storage backend...", cfg.InputFilePath)
+		in, err := os.Open(cfg.InputFilePath)
+		if err != nil {
+			return job, fmt.Errorf("open input file: %w", err)
 		}
-		log.Printf("Upload completed.")
+		err = p.store.Put(ctx, job.S3Key, in)
+		in.Close()
+		if err != nil {
+			return job, fmt.Errorf("upload file: %w", err)
+		}
+		log.Printf("[%s] upload completed.", cfg.InputFilePath)
 	}
 
-	transcribeClient := transcribe.NewFromConfig(awsCfg)
-	transcribeService := aws.NewTranscribeService(transcribeClient)
-	if err := transcribeService.EnsureTranscriptionJob(ctx, jobName, cfgApp.BucketName, s3Key, cfgApp); err != nil {
-		return fmt.Errorf("ensuring transcription job: %w", err)
+	if err := p.transcribeService.EnsureTranscriptionJob(ctx, job.JobName, cfg.BucketName, job.S3Key, &cfg); err != nil {
+		return job, fmt.Errorf("ensuring transcription job: %w", err)
 	}
-	log.Printf("Transcription job completed.")
+	log.Printf("[%s] transcription job completed.", cfg.InputFilePath)
 
 	// By default, Transcribe names the output file "<jobName>.json" in the provided bucket.
-	transcriptionKey := fmt.Sprintf("%s.json", jobName)
-	log.Printf("Retrieving transcription result from S3: %s", transcriptionKey)
-	transcript, err := s3Service.GetTranscriptFromS3(ctx, cfgApp.BucketName, transcriptionKey, cfgApp)
+	transcriptionKey := fmt.Sprintf("%s.json", job.JobName)
+	log.Printf("[%s] retrieving transcription result: %s", cfg.InputFilePath, transcriptionKey)
+
+	result, err := p.store.Get(ctx, transcriptionKey)
 	if err != nil {
-		return fmt.Errorf("retrieve transcription result: %w", err)
+		return job, fmt.Errorf("retrieve transcription result: %w", err)
 	}
+	defer result.Close()
 
-	if err := os.WriteFile(cfgApp.OutputFilePath, []byte(transcript), 0o644); err != nil {
-		return fmt.Errorf("write transcript to file: %w", err)
+	out, err := os.Create(cfg.OutputFilePath)
+	if err != nil {
+		return job, fmt.Errorf("create output file: %w", err)
+	}
+	defer out.Close()
+
+	if err := aws.RenderTranscript(result, &cfg, out); err != nil {
+		return job, fmt.Errorf("render transcription result: %w", err)
+	}
+	log.Printf("[%s] transcript saved to %q", cfg.InputFilePath, cfg.OutputFilePath)
+	return job, nil
+}
+
+// runStorageCheck uploads cfgApp.InputFilePath to cfgApp.StorageURL under a
+// throwaway key and reads it back, to confirm the configured backend is
+// reachable and read/write capable without involving Transcribe at all. It's
+// the only entrypoint that exercises the file://, gs://, and S3-compatible
+// storage backends, since Transcribe itself only ever talks to Amazon S3.
+func runStorageCheck(ctx context.Context, store storage.ObjectStore, cfgApp *types.AppConfig) error {
+	f, err := os.Open(cfgApp.InputFilePath)
+	if err != nil {
+		return fmt.Errorf("open input file: %w", err)
 	}
+	defer f.Close()
+
+	key := fmt.Sprintf("storage-check/%s", filepath.Base(cfgApp.InputFilePath))
+	log.Printf("uploading %q to %q to check storage backend %q...", cfgApp.InputFilePath, key, cfgApp.StorageURL)
+	if err := store.Put(ctx, key, f); err != nil {
+		return fmt.Errorf("upload file: %w", err)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("read file back: %w", err)
+	}
+	defer r.Close()
+
+	n, err := io.Copy(io.Discard, r)
+	if err != nil {
+		return fmt.Errorf("read file back: %w", err)
+	}
+
+	log.Printf("storage backend %q OK: uploaded and read back %d bytes at %q", cfgApp.StorageURL, n, key)
+	return nil
+}
+
+// runStreaming transcribes cfgApp.InputFilePath (or stdin, if "-") in near-real-time,
+// printing partial results live and appending finalized segments to the output file.
+func runStreaming(ctx context.Context, svc *aws.StreamingTranscribeService, cfgApp *types.AppConfig) error {
+	var audio io.Reader = os.Stdin
+	if cfgApp.InputFilePath != "-" {
+		f, err := os.Open(cfgApp.InputFilePath)
+		if err != nil {
+			return fmt.Errorf("open input file: %w", err)
+		}
+		defer f.Close()
+		audio = f
+	}
+
+	out, err := os.Create(cfgApp.OutputFilePath)
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+	defer out.Close()
+
+	events, err := svc.Transcribe(ctx, audio, cfgApp)
+	if err != nil {
+		return fmt.Errorf("start streaming transcription: %w", err)
+	}
+
+	var lastSpeaker string
+	for event := range events {
+		if event.IsPartial {
+			log.Printf("(partial) %s", event.Text)
+			continue
+		}
+		if cfgApp.SpeakerDiarization && event.Speaker != "" && event.Speaker != lastSpeaker {
+			if lastSpeaker != "" {
+				if _, err := fmt.Fprintln(out); err != nil {
+					return fmt.Errorf("write speaker separator: %w", err)
+				}
+			}
+			lastSpeaker = event.Speaker
+			if _, err := fmt.Fprint(out, formatting.SpeakerPrefix(event.Speaker)); err != nil {
+				return fmt.Errorf("write speaker label: %w", err)
+			}
+		}
+		if _, err := fmt.Fprintln(out, event.Text); err != nil {
+			return fmt.Errorf("write transcript segment: %w", err)
+		}
+	}
+
 	log.Printf("Transcript saved to %q", cfgApp.OutputFilePath)
 	return nil
 }